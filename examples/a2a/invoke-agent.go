@@ -1,252 +1,19 @@
+// Command invoke-agent is a thin CLI wrapper over pkg/gatewayclient,
+// demonstrating the A2A transports (non-streaming, SSE, WebSocket) exposed
+// by the Gateway.
 package main
 
 import (
-	"bytes"
+	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
+	"strings"
 	"time"
-)
-
-// GatewayClient represents a client for the AI Security Gateway A2A API
-type GatewayClient struct {
-	BaseURL string
-	APIKey  string
-	Client  *http.Client
-}
-
-// NewGatewayClient creates a new Gateway client
-func NewGatewayClient(baseURL, apiKey string) *GatewayClient {
-	return &GatewayClient{
-		BaseURL: baseURL,
-		APIKey:  apiKey,
-		Client: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-	}
-}
-
-// InvokeAgentRequest represents the request body for agent invocation
-type InvokeAgentRequest struct {
-	Message struct {
-		Role  string `json:"role"`
-		Parts []struct {
-			Kind string `json:"kind"`
-			Text string `json:"text"`
-		} `json:"parts"`
-	} `json:"message"`
-	Streaming bool `json:"streaming,omitempty"`
-}
-
-// InvokeAgentResponse represents the response from agent invocation
-type InvokeAgentResponse struct {
-	Success bool        `json:"success"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   string      `json:"error,omitempty"`
-}
-
-// InvokeAgent invokes an A2A agent via the Gateway
-func (c *GatewayClient) InvokeAgent(ctx context.Context, agentID int, message string, streaming bool) error {
-	// Build request
-	reqBody := InvokeAgentRequest{
-		Streaming: streaming,
-	}
-	reqBody.Message.Role = "user"
-	reqBody.Message.Parts = []struct {
-		Kind string `json:"kind"`
-		Text string `json:"text"`
-	}{
-		{Kind: "text", Text: message},
-	}
-
-	jsonData, err := json.Marshal(reqBody)
-	if err != nil {
-		return fmt.Errorf("failed to marshal request: %w", err)
-	}
-
-	// Create HTTP request
-	url := fmt.Sprintf("%s/api/v1/agents/%d/invoke", c.BaseURL, agentID)
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-API-Key", c.APIKey)
-
-	// Handle streaming vs non-streaming
-	if streaming {
-		req.Header.Set("Accept", "text/event-stream")
-		return c.handleStreamingResponse(ctx, req)
-	}
-
-	return c.handleNonStreamingResponse(ctx, req)
-}
-
-// handleNonStreamingResponse handles a non-streaming response
-func (c *GatewayClient) handleNonStreamingResponse(ctx context.Context, req *http.Request) error {
-	resp, err := c.Client.Do(req)
-	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
-	}
-
-	// Check for rate limit errors
-	if resp.StatusCode == http.StatusTooManyRequests {
-		var errorResp InvokeAgentResponse
-		if err := json.Unmarshal(body, &errorResp); err == nil {
-			return fmt.Errorf("rate limit exceeded: %s", errorResp.Error)
-		}
-		return fmt.Errorf("rate limit exceeded (HTTP %d)", resp.StatusCode)
-	}
-
-	// Check for other errors
-	if resp.StatusCode != http.StatusOK {
-		var errorResp InvokeAgentResponse
-		if err := json.Unmarshal(body, &errorResp); err == nil {
-			return fmt.Errorf("invocation failed: %s (code: %s)", errorResp.Error, errorResp.Error)
-		}
-		return fmt.Errorf("invocation failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
-	// Parse successful response
-	var response InvokeAgentResponse
-	if err := json.Unmarshal(body, &response); err != nil {
-		return fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	if !response.Success {
-		return fmt.Errorf("invocation failed: %s", response.Error)
-	}
-
-	// Pretty print response
-	prettyJSON, err := json.MarshalIndent(response.Data, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to format response: %w", err)
-	}
-
-	fmt.Println("Agent Response:")
-	fmt.Println(string(prettyJSON))
-	return nil
-}
-
-// handleStreamingResponse handles a streaming response (SSE)
-func (c *GatewayClient) handleStreamingResponse(ctx context.Context, req *http.Request) error {
-	resp, err := c.Client.Do(req)
-	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("streaming failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
-	fmt.Println("Streaming Response (SSE):")
-	fmt.Println("---")
-
-	// Read SSE stream
-	buf := make([]byte, 4096)
-	var eventBuffer []byte
-
-	for {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		default:
-			n, err := resp.Body.Read(buf)
-			if err != nil && err != io.EOF {
-				return fmt.Errorf("failed to read stream: %w", err)
-			}
-			if n == 0 {
-				if err == io.EOF {
-					return nil
-				}
-				continue
-			}
-
-			eventBuffer = append(eventBuffer, buf[:n]...)
-
-			// Process complete events (lines ending with \n\n)
-			for {
-				idx := bytes.Index(eventBuffer, []byte("\n\n"))
-				if idx == -1 {
-					break
-				}
-
-				event := string(eventBuffer[:idx])
-				eventBuffer = eventBuffer[idx+2:]
-
-				// Parse SSE event
-				if bytes.HasPrefix([]byte(event), []byte("data: ")) {
-					data := event[6:] // Skip "data: "
-					fmt.Println(data)
-				} else if bytes.HasPrefix([]byte(event), []byte("event: ")) {
-					eventType := event[7:] // Skip "event: "
-					fmt.Printf("[Event: %s]\n", eventType)
-				}
-			}
-		}
-	}
-}
-
-// ListAgents lists all accessible agents
-func (c *GatewayClient) ListAgents(ctx context.Context) error {
-	url := fmt.Sprintf("%s/api/v1/agents", c.BaseURL)
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("X-API-Key", c.APIKey)
-
-	resp, err := c.Client.Do(req)
-	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("list failed with status %d: %s", resp.StatusCode, string(body))
-	}
 
-	var response struct {
-		Success bool `json:"success"`
-		Data    struct {
-			Agents []struct {
-				ID     int    `json:"id"`
-				Name   string `json:"name"`
-				Status string `json:"status"`
-			} `json:"agents"`
-		} `json:"data"`
-	}
-
-	if err := json.Unmarshal(body, &response); err != nil {
-		return fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	fmt.Println("Available Agents:")
-	fmt.Println("---")
-	for _, agent := range response.Data.Agents {
-		fmt.Printf("ID: %d | Name: %s | Status: %s\n", agent.ID, agent.Name, agent.Status)
-	}
-
-	return nil
-}
+	"github.com/syphon1c/ai-security-gateway/pkg/gatewayclient"
+)
 
 func main() {
 	if len(os.Args) < 2 {
@@ -256,6 +23,7 @@ func main() {
 		fmt.Println("  list <api-key>                    List available agents")
 		fmt.Println("  invoke <api-key> <agent-id> <msg> Invoke agent (non-streaming)")
 		fmt.Println("  stream <api-key> <agent-id> <msg> Invoke agent (streaming)")
+		fmt.Println("  ws <api-key> <agent-id>           Open a bidirectional session (REPL)")
 		fmt.Println("")
 		fmt.Println("Environment Variables:")
 		fmt.Println("  GATEWAY_URL  Gateway URL (default: http://localhost:8080)")
@@ -277,16 +45,14 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancel()
 
-	client := NewGatewayClient(gatewayURL, "")
-
 	switch command {
 	case "list":
 		if len(os.Args) < 3 {
 			fmt.Println("Error: API key required")
 			os.Exit(1)
 		}
-		client.APIKey = os.Args[2]
-		if err := client.ListAgents(ctx); err != nil {
+		client := gatewayclient.New(gatewayURL, os.Args[2])
+		if err := runList(ctx, client); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
@@ -296,14 +62,13 @@ func main() {
 			fmt.Println("Error: API key, agent ID, and message required")
 			os.Exit(1)
 		}
-		client.APIKey = os.Args[2]
-		agentID := 0
-		if _, err := fmt.Sscanf(os.Args[3], "%d", &agentID); err != nil {
-			fmt.Printf("Error: Invalid agent ID: %s\n", os.Args[3])
+		agentID, err := parseAgentID(os.Args[3])
+		if err != nil {
+			fmt.Println(err)
 			os.Exit(1)
 		}
-		message := os.Args[4]
-		if err := client.InvokeAgent(ctx, agentID, message, false); err != nil {
+		client := gatewayclient.New(gatewayURL, os.Args[2])
+		if err := runInvoke(ctx, client, agentID, os.Args[4]); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
@@ -313,14 +78,29 @@ func main() {
 			fmt.Println("Error: API key, agent ID, and message required")
 			os.Exit(1)
 		}
-		client.APIKey = os.Args[2]
-		agentID := 0
-		if _, err := fmt.Sscanf(os.Args[3], "%d", &agentID); err != nil {
-			fmt.Printf("Error: Invalid agent ID: %s\n", os.Args[3])
+		agentID, err := parseAgentID(os.Args[3])
+		if err != nil {
+			fmt.Println(err)
 			os.Exit(1)
 		}
-		message := os.Args[4]
-		if err := client.InvokeAgent(ctx, agentID, message, true); err != nil {
+		client := gatewayclient.New(gatewayURL, os.Args[2])
+		if err := runStream(ctx, client, agentID, os.Args[4]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+	case "ws":
+		if len(os.Args) < 4 {
+			fmt.Println("Error: API key and agent ID required")
+			os.Exit(1)
+		}
+		agentID, err := parseAgentID(os.Args[3])
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		client := gatewayclient.New(gatewayURL, os.Args[2])
+		if err := runWSRepl(ctx, client, agentID); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
@@ -331,3 +111,114 @@ func main() {
 	}
 }
 
+// parseAgentID parses a CLI agent-id argument, returning a user-facing
+// error on failure.
+func parseAgentID(arg string) (int, error) {
+	var agentID int
+	if _, err := fmt.Sscanf(arg, "%d", &agentID); err != nil {
+		return 0, fmt.Errorf("Error: Invalid agent ID: %s", arg)
+	}
+	return agentID, nil
+}
+
+// runList prints the agents accessible to the client's API key.
+func runList(ctx context.Context, client *gatewayclient.Client) error {
+	agents, err := client.ListAgents(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Available Agents:")
+	fmt.Println("---")
+	for _, agent := range agents {
+		fmt.Printf("ID: %d | Name: %s | Status: %s\n", agent.ID, agent.Name, agent.Status)
+	}
+	return nil
+}
+
+// runInvoke performs a non-streaming invocation and pretty-prints the result.
+func runInvoke(ctx context.Context, client *gatewayclient.Client, agentID int, message string) error {
+	result, err := client.InvokeAgent(ctx, agentID, message)
+	if err != nil {
+		return err
+	}
+
+	prettyJSON, err := json.MarshalIndent(result.Data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to format response: %w", err)
+	}
+
+	fmt.Println("Agent Response:")
+	fmt.Println(string(prettyJSON))
+	return nil
+}
+
+// runStream performs a streaming invocation, printing each SSE event as it
+// arrives.
+func runStream(ctx context.Context, client *gatewayclient.Client, agentID int, message string) error {
+	fmt.Println("Streaming Response (SSE):")
+	fmt.Println("---")
+
+	events, errs := client.StreamAgent(ctx, agentID, message)
+	for events != nil || errs != nil {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			if event.EventType != "" {
+				fmt.Printf("[Event: %s]\n", event.EventType)
+			}
+			if event.Data != "" {
+				fmt.Println(event.Data)
+			}
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// runWSRepl drops the user into an interactive REPL over a bidirectional
+// agent session, printing each reply as it arrives.
+func runWSRepl(ctx context.Context, client *gatewayclient.Client, agentID int) error {
+	session, err := client.InvokeAgentWS(ctx, agentID)
+	if err != nil {
+		return fmt.Errorf("failed to open session: %w", err)
+	}
+	defer session.Close()
+
+	fmt.Println("Connected. Type a message and press Enter; Ctrl+D to quit.")
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for {
+		fmt.Print("> ")
+		if !scanner.Scan() {
+			return scanner.Err()
+		}
+
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if err := session.Send(line); err != nil {
+			return fmt.Errorf("failed to send message: %w", err)
+		}
+
+		event, err := session.Recv()
+		if err != nil {
+			return fmt.Errorf("failed to receive reply: %w", err)
+		}
+
+		fmt.Println(event.Data)
+	}
+}
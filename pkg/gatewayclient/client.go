@@ -0,0 +1,108 @@
+// Package gatewayclient is a Go client library for the AI Security Gateway
+// A2A API. It wraps agent invocation (non-streaming, SSE streaming, and
+// WebSocket) behind a single Client, so the behaviour exercised by the
+// gateway's own CLI is also available to other Go programs and tests.
+package gatewayclient
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Client is a client for the AI Security Gateway A2A API.
+type Client struct {
+	baseURL     string
+	apiKey      string
+	httpClient  *http.Client
+	retryPolicy RetryPolicy
+	userAgent   string
+	baseHeaders http.Header
+	propagator  Propagator
+}
+
+// Option configures a Client constructed by New.
+type Option func(*Client)
+
+// WithHTTPClient overrides the default *http.Client used for all requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTimeout sets the timeout on the Client's underlying *http.Client.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.httpClient.Timeout = timeout
+	}
+}
+
+// WithRetryPolicy overrides the default retry policy for non-streaming
+// invocations.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithBaseHeaders sets additional headers to send with every request.
+func WithBaseHeaders(headers http.Header) Option {
+	return func(c *Client) {
+		c.baseHeaders = headers.Clone()
+	}
+}
+
+// WithPropagator overrides the default W3C Trace Context propagator used to
+// inject tracing headers derived from the call's context.Context.
+func WithPropagator(propagator Propagator) Option {
+	return func(c *Client) {
+		c.propagator = propagator
+	}
+}
+
+// New creates a Client for the Gateway at baseURL authenticating with
+// apiKey, applying any given Options.
+func New(baseURL, apiKey string, opts ...Option) *Client {
+	c := &Client{
+		baseURL: baseURL,
+		apiKey:  apiKey,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		retryPolicy: DefaultRetryPolicy(),
+		userAgent:   "gatewayclient/1.0",
+		propagator:  W3CPropagator{},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// newRequestHeaders returns the base set of headers applied to every
+// outgoing request: authentication, user agent, any caller-supplied base
+// headers, a generated or caller-supplied X-Request-ID, and tracing headers
+// propagated from ctx.
+func (c *Client) newRequestHeaders(ctx context.Context) http.Header {
+	headers := c.baseHeaders.Clone()
+	if headers == nil {
+		headers = http.Header{}
+	}
+	headers.Set("X-API-Key", c.apiKey)
+	headers.Set("User-Agent", c.userAgent)
+	if headers.Get("X-Request-ID") == "" {
+		headers.Set("X-Request-ID", newRequestID())
+	}
+	c.propagator.Inject(ctx, headers)
+	return headers
+}
@@ -0,0 +1,60 @@
+package gatewayclient
+
+import (
+	"fmt"
+	"time"
+)
+
+// RateLimitError is returned when the Gateway rejects a call with HTTP 429
+// after the retry policy has been exhausted.
+type RateLimitError struct {
+	Message    string
+	RetryAfter time.Duration
+	RequestID  string
+}
+
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("rate limit exceeded, retry after %s: %s (request %s)", e.RetryAfter, e.Message, e.RequestID)
+	}
+	return fmt.Sprintf("rate limit exceeded: %s (request %s)", e.Message, e.RequestID)
+}
+
+// AuthError is returned when the Gateway rejects a call with HTTP 401 or
+// 403, indicating a missing or invalid API key.
+type AuthError struct {
+	StatusCode int
+	RequestID  string
+}
+
+func (e *AuthError) Error() string {
+	return fmt.Sprintf("authentication failed with status %d (request %s)", e.StatusCode, e.RequestID)
+}
+
+// AgentNotFoundError is returned when the Gateway reports HTTP 404 for an
+// agent ID.
+type AgentNotFoundError struct {
+	AgentID   int
+	RequestID string
+}
+
+func (e *AgentNotFoundError) Error() string {
+	return fmt.Sprintf("agent %d not found (request %s)", e.AgentID, e.RequestID)
+}
+
+// GatewayError is returned for any other non-success response from the
+// Gateway, carrying the HTTP status and the server-side error code/message
+// when available.
+type GatewayError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	RequestID  string
+}
+
+func (e *GatewayError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("gateway error %d (%s): %s (request %s)", e.StatusCode, e.Code, e.Message, e.RequestID)
+	}
+	return fmt.Sprintf("gateway error %d: %s (request %s)", e.StatusCode, e.Message, e.RequestID)
+}
@@ -0,0 +1,112 @@
+package gatewayclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// AgentTarget identifies one agent to invoke as part of a fan-out call.
+type AgentTarget struct {
+	AgentID int
+	// Deadline, if non-zero, bounds this target's invocation independently
+	// of the parent context and the other targets.
+	Deadline time.Duration
+}
+
+// FanOutOptions configures InvokeAgents.
+type FanOutOptions struct {
+	// Concurrency bounds how many targets are invoked at once. Zero or
+	// negative means all targets run concurrently.
+	Concurrency int
+	// FailFast cancels all in-flight and not-yet-dispatched targets as soon
+	// as one target returns an error.
+	FailFast bool
+	// QuorumN, if non-zero, cancels remaining targets once this many
+	// successful results have been delivered.
+	QuorumN int
+}
+
+// AgentResult is one target's outcome from InvokeAgents, tagged by agent ID.
+type AgentResult struct {
+	AgentID int
+	Result  *InvokeResult
+	Err     error
+}
+
+// InvokeAgents dispatches the same message to every target concurrently,
+// bounded by opts.Concurrency, aggregating results onto a single channel
+// tagged by agent ID. Workers share the Client's underlying http.Client
+// connection pool.
+//
+// results is buffered to len(targets), so every dispatched worker's send
+// always succeeds without blocking. FailFast/QuorumN cancel the context of
+// in-flight targets (which then report a context error) and stop dispatching
+// the rest; they never drop a result that a worker has already produced.
+// The returned channel is closed once every dispatched worker has returned.
+func (c *Client) InvokeAgents(ctx context.Context, targets []AgentTarget, message string, opts FanOutOptions) (<-chan AgentResult, error) {
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("gatewayclient: no targets provided")
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = len(targets)
+	}
+
+	fanCtx, cancel := context.WithCancel(ctx)
+	sem := make(chan struct{}, concurrency)
+	results := make(chan AgentResult, len(targets))
+
+	var wg sync.WaitGroup
+	var successCount int32
+
+dispatch:
+	for _, target := range targets {
+		target := target
+
+		select {
+		case <-fanCtx.Done():
+			break dispatch
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			workerCtx := fanCtx
+			if target.Deadline > 0 {
+				var workerCancel context.CancelFunc
+				workerCtx, workerCancel = context.WithTimeout(fanCtx, target.Deadline)
+				defer workerCancel()
+			}
+
+			result, err := c.InvokeAgent(workerCtx, target.AgentID, message)
+
+			// results is buffered to len(targets), so this send is always
+			// non-blocking; do not race it against fanCtx.Done() or a
+			// result a worker has already produced can be dropped right
+			// as FailFast/QuorumN cancels the remaining targets.
+			results <- AgentResult{AgentID: target.AgentID, Result: result, Err: err}
+
+			switch {
+			case err != nil && opts.FailFast:
+				cancel()
+			case err == nil && opts.QuorumN > 0 && atomic.AddInt32(&successCount, 1) >= int32(opts.QuorumN):
+				cancel()
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		cancel()
+		close(results)
+	}()
+
+	return results, nil
+}
@@ -0,0 +1,157 @@
+package gatewayclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newFanoutTestServer returns a server where every agent ID other than
+// failingID succeeds and failingID fails with a 500; delay is applied to every
+// request so concurrency can be observed.
+func newFanoutTestServer(t *testing.T, failingID int, delay time.Duration, inFlight, maxInFlight *int32) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if inFlight != nil {
+			cur := atomic.AddInt32(inFlight, 1)
+			defer atomic.AddInt32(inFlight, -1)
+			for {
+				max := atomic.LoadInt32(maxInFlight)
+				if cur <= max || atomic.CompareAndSwapInt32(maxInFlight, max, cur) {
+					break
+				}
+			}
+		}
+
+		time.Sleep(delay)
+
+		var agentID int
+		fmt.Sscanf(r.URL.Path, "/api/v1/agents/%d/invoke", &agentID)
+
+		if agentID == failingID {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(map[string]interface{}{"success": false, "error": "boom"})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "data": map[string]int{"agentID": agentID}})
+	}))
+}
+
+func TestInvokeAgentsReturnsOneResultPerTarget(t *testing.T) {
+	server := newFanoutTestServer(t, -1, 0, nil, nil)
+	defer server.Close()
+
+	client := New(server.URL, "test-key")
+	targets := []AgentTarget{{AgentID: 1}, {AgentID: 2}, {AgentID: 3}}
+
+	results, err := client.InvokeAgents(context.Background(), targets, "hi", FanOutOptions{})
+	if err != nil {
+		t.Fatalf("InvokeAgents: %v", err)
+	}
+
+	seen := map[int]bool{}
+	for result := range results {
+		if result.Err != nil {
+			t.Fatalf("unexpected error for agent %d: %v", result.AgentID, result.Err)
+		}
+		seen[result.AgentID] = true
+	}
+
+	for _, target := range targets {
+		if !seen[target.AgentID] {
+			t.Errorf("missing result for agent %d", target.AgentID)
+		}
+	}
+}
+
+func TestInvokeAgentsFailFastReportsError(t *testing.T) {
+	server := newFanoutTestServer(t, 2, 0, nil, nil)
+	defer server.Close()
+
+	client := New(server.URL, "test-key")
+	targets := []AgentTarget{{AgentID: 1}, {AgentID: 2}, {AgentID: 3}}
+
+	results, err := client.InvokeAgents(context.Background(), targets, "hi", FanOutOptions{FailFast: true})
+	if err != nil {
+		t.Fatalf("InvokeAgents: %v", err)
+	}
+
+	var sawFailure bool
+	for result := range results {
+		if result.AgentID == 2 {
+			if result.Err == nil {
+				t.Errorf("expected agent 2 to fail")
+			}
+			sawFailure = true
+		}
+	}
+
+	if !sawFailure {
+		t.Errorf("never observed the failing target's result")
+	}
+}
+
+func TestInvokeAgentsQuorumStopsAfterNSuccesses(t *testing.T) {
+	server := newFanoutTestServer(t, -1, 10*time.Millisecond, nil, nil)
+	defer server.Close()
+
+	client := New(server.URL, "test-key")
+	targets := []AgentTarget{{AgentID: 1}, {AgentID: 2}, {AgentID: 3}, {AgentID: 4}, {AgentID: 5}}
+
+	results, err := client.InvokeAgents(context.Background(), targets, "hi", FanOutOptions{QuorumN: 2, Concurrency: 1})
+	if err != nil {
+		t.Fatalf("InvokeAgents: %v", err)
+	}
+
+	var successes int
+	for result := range results {
+		if result.Err == nil {
+			successes++
+		}
+	}
+
+	if successes != 2 {
+		t.Errorf("got %d successes, want exactly the quorum of 2", successes)
+	}
+}
+
+func TestInvokeAgentsRespectsConcurrencyBound(t *testing.T) {
+	var inFlight, maxInFlight int32
+
+	server := newFanoutTestServer(t, -1, 20*time.Millisecond, &inFlight, &maxInFlight)
+	defer server.Close()
+
+	client := New(server.URL, "test-key")
+	targets := make([]AgentTarget, 0, 6)
+	for i := 1; i <= 6; i++ {
+		targets = append(targets, AgentTarget{AgentID: i})
+	}
+
+	results, err := client.InvokeAgents(context.Background(), targets, "hi", FanOutOptions{Concurrency: 2})
+	if err != nil {
+		t.Fatalf("InvokeAgents: %v", err)
+	}
+
+	for range results {
+	}
+
+	if got := atomic.LoadInt32(&maxInFlight); got > 2 {
+		t.Errorf("observed %d concurrent in-flight requests, want at most 2", got)
+	}
+}
+
+func TestInvokeAgentsRejectsEmptyTargets(t *testing.T) {
+	client := New("http://example.invalid", "test-key")
+
+	if _, err := client.InvokeAgents(context.Background(), nil, "hi", FanOutOptions{}); err == nil {
+		t.Error("expected an error for no targets, got nil")
+	}
+}
@@ -0,0 +1,193 @@
+package gatewayclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// InvokeAgent invokes an A2A agent via the Gateway and returns its decoded
+// result. It automatically retries per c.retryPolicy, since a non-streaming
+// invoke has not emitted any partial output.
+func (c *Client) InvokeAgent(ctx context.Context, agentID int, message string) (*InvokeResult, error) {
+	req, err := c.newInvokeRequest(ctx, agentID, message, false)
+	if err != nil {
+		return nil, err
+	}
+
+	body, status, headers, err := c.doWithRetry(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	requestID := headers.Get("X-Request-ID")
+
+	var errorResp invokeAgentResponse
+	_ = json.Unmarshal(body, &errorResp)
+
+	switch {
+	case status == http.StatusTooManyRequests:
+		return nil, &RateLimitError{
+			Message:    errorResp.Error,
+			RetryAfter: parseRetryAfter(headers.Get("Retry-After")),
+			RequestID:  requestID,
+		}
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return nil, &AuthError{StatusCode: status, RequestID: requestID}
+	case status == http.StatusNotFound:
+		return nil, &AgentNotFoundError{AgentID: agentID, RequestID: requestID}
+	case status != http.StatusOK:
+		message := errorResp.Error
+		if message == "" {
+			message = string(body)
+		}
+		return nil, &GatewayError{StatusCode: status, Code: errorResp.ErrorCode, Message: message, RequestID: requestID}
+	}
+
+	if !errorResp.Success {
+		return nil, &GatewayError{StatusCode: status, Code: errorResp.ErrorCode, Message: errorResp.Error, RequestID: requestID}
+	}
+
+	return &InvokeResult{Data: errorResp.Data}, nil
+}
+
+// newInvokeRequest builds the HTTP request for an agent invocation, shared
+// by the non-streaming, SSE, and retry paths.
+func (c *Client) newInvokeRequest(ctx context.Context, agentID int, message string, streaming bool) (*http.Request, error) {
+	reqBody := invokeAgentRequest{
+		Message:   Message{Role: "user", Parts: []MessagePart{{Kind: "text", Text: message}}},
+		Streaming: streaming,
+	}
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/v1/agents/%d/invoke", c.baseURL, agentID)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header = c.newRequestHeaders(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	if streaming {
+		req.Header.Set("Accept", "text/event-stream")
+	}
+
+	return req, nil
+}
+
+// doWithRetry executes req, retrying according to c.retryPolicy on network
+// errors and the configured retryable statuses. It returns the final
+// response body, status code, and headers (so callers can read
+// X-Request-ID and Retry-After), or the last error once attempts are
+// exhausted. A req with a body must have GetBody set (true for any request
+// built with a bytes.Buffer/Reader body) so it can be replayed across
+// attempts; a req without GetBody is reused as is.
+func (c *Client) doWithRetry(ctx context.Context, req *http.Request) ([]byte, int, http.Header, error) {
+	policy := c.retryPolicy
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+
+	var lastErr error
+
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		attemptReq := req
+		if attempt > 1 && req.GetBody != nil {
+			rc, err := req.GetBody()
+			if err != nil {
+				return nil, 0, nil, fmt.Errorf("failed to rebuild request for retry: %w", err)
+			}
+			clone := req.Clone(ctx)
+			clone.Body = rc
+			attemptReq = clone
+		}
+
+		resp, err := c.httpClient.Do(attemptReq)
+		if err != nil {
+			lastErr = fmt.Errorf("request failed: %w", err)
+			if attempt == policy.MaxAttempts || !retryableError(err) {
+				return nil, 0, nil, lastErr
+			}
+			c.waitForRetry(ctx, &policy, attempt, lastErr, 0)
+			continue
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, 0, nil, fmt.Errorf("failed to read response: %w", err)
+		}
+
+		if !policy.RetryableStatuses[resp.StatusCode] || attempt == policy.MaxAttempts {
+			return body, resp.StatusCode, resp.Header, nil
+		}
+
+		lastErr = fmt.Errorf("received retryable status %d", resp.StatusCode)
+		retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
+		c.waitForRetry(ctx, &policy, attempt, lastErr, retryAfter)
+	}
+
+	return nil, 0, nil, lastErr
+}
+
+// waitForRetry sleeps for the backoff delay of the given attempt, invoking
+// policy.OnRetry beforehand and returning early if ctx is cancelled.
+func (c *Client) waitForRetry(ctx context.Context, policy *RetryPolicy, attempt int, err error, retryAfter time.Duration) {
+	delay := policy.backoffForAttempt(attempt, retryAfter)
+	if policy.OnRetry != nil {
+		policy.OnRetry(attempt, err, delay)
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(delay):
+	}
+}
+
+// ListAgents lists all agents accessible to the caller's API key, retrying
+// per c.retryPolicy like InvokeAgent.
+func (c *Client) ListAgents(ctx context.Context) ([]Agent, error) {
+	url := fmt.Sprintf("%s/api/v1/agents", c.baseURL)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header = c.newRequestHeaders(ctx)
+
+	body, status, headers, err := c.doWithRetry(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	requestID := headers.Get("X-Request-ID")
+	switch {
+	case status == http.StatusTooManyRequests:
+		return nil, &RateLimitError{
+			Message:    string(body),
+			RetryAfter: parseRetryAfter(headers.Get("Retry-After")),
+			RequestID:  requestID,
+		}
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return nil, &AuthError{StatusCode: status, RequestID: requestID}
+	case status != http.StatusOK:
+		return nil, &GatewayError{
+			StatusCode: status,
+			Message:    string(body),
+			RequestID:  requestID,
+		}
+	}
+
+	var response listAgentsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return response.Data.Agents, nil
+}
@@ -0,0 +1,110 @@
+package gatewayclient
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy configures automatic retry-with-backoff for idempotent
+// gateway calls. The zero value makes a single attempt with no retries; use
+// DefaultRetryPolicy for the default retry behaviour.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between retries.
+	MaxBackoff time.Duration
+	// Multiplier scales the backoff after each retry.
+	Multiplier float64
+	// Jitter is the fraction (0-1) of random variance applied to each delay.
+	Jitter float64
+	// RetryableStatuses lists the HTTP status codes that should be retried.
+	RetryableStatuses map[int]bool
+	// OnRetry, if set, is called before each retry with the attempt number
+	// (1-indexed), the error that triggered the retry, and the delay about
+	// to be applied.
+	OnRetry func(attempt int, err error, delay time.Duration)
+}
+
+// DefaultRetryPolicy retries 429, 502, 503, 504 and network errors up to 3
+// attempts with a 500ms base delay and a 30s cap.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		RetryableStatuses: map[int]bool{
+			http.StatusTooManyRequests:    true,
+			http.StatusBadGateway:         true,
+			http.StatusServiceUnavailable: true,
+			http.StatusGatewayTimeout:     true,
+		},
+	}
+}
+
+// retryableError reports whether err represents a network-level failure
+// (as opposed to a well-formed HTTP error response) and should therefore be
+// retried regardless of RetryableStatuses.
+func retryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+	return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+// backoffForAttempt computes the delay before the given retry attempt
+// (1-indexed), honoring a server-provided Retry-After delay when present.
+func (p RetryPolicy) backoffForAttempt(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return retryAfter
+	}
+
+	delay := float64(p.InitialBackoff) * pow(p.Multiplier, attempt-1)
+	if max := float64(p.MaxBackoff); delay > max {
+		delay = max
+	}
+
+	if p.Jitter > 0 {
+		delta := delay * p.Jitter
+		delay = delay - delta + rand.Float64()*2*delta
+	}
+
+	return time.Duration(delay)
+}
+
+// pow computes base^exp for non-negative integer exponents without pulling
+// in math.Pow's float edge-case handling, which isn't needed here.
+func pow(base float64, exp int) float64 {
+	result := 1.0
+	for i := 0; i < exp; i++ {
+		result *= base
+	}
+	return result
+}
+
+// parseRetryAfter parses a Retry-After header value in either delta-seconds
+// or HTTP-date form, per RFC 7231 §7.1.3. It returns zero if the header is
+// absent or unparseable.
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+
+	if seconds, err := time.ParseDuration(header + "s"); err == nil {
+		return seconds
+	}
+
+	if when, err := http.ParseTime(header); err == nil {
+		if d := time.Until(when); d > 0 {
+			return d
+		}
+	}
+
+	return 0
+}
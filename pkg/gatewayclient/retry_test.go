@@ -0,0 +1,86 @@
+package gatewayclient
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestBackoffForAttemptHonorsRetryAfter(t *testing.T) {
+	policy := DefaultRetryPolicy()
+
+	got := policy.backoffForAttempt(1, 5*time.Second)
+	if got != 5*time.Second {
+		t.Errorf("backoffForAttempt with Retry-After = %v, want %v", got, 5*time.Second)
+	}
+}
+
+func TestBackoffForAttemptGrowsAndCaps(t *testing.T) {
+	policy := RetryPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     1 * time.Second,
+		Multiplier:     2,
+		Jitter:         0, // deterministic for this test
+	}
+
+	cases := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{5, 1 * time.Second}, // 1.6s uncapped, capped to MaxBackoff
+	}
+
+	for _, tt := range cases {
+		got := policy.backoffForAttempt(tt.attempt, 0)
+		if got != tt.want {
+			t.Errorf("backoffForAttempt(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestBackoffForAttemptAppliesJitterWithinBounds(t *testing.T) {
+	policy := RetryPolicy{
+		InitialBackoff: 1 * time.Second,
+		MaxBackoff:     10 * time.Second,
+		Multiplier:     1,
+		Jitter:         0.2,
+	}
+
+	for i := 0; i < 20; i++ {
+		got := policy.backoffForAttempt(1, 0)
+		min := 800 * time.Millisecond
+		max := 1200 * time.Millisecond
+		if got < min || got > max {
+			t.Fatalf("backoffForAttempt jitter out of bounds: got %v, want within [%v, %v]", got, min, max)
+		}
+	}
+}
+
+func TestParseRetryAfterSeconds(t *testing.T) {
+	got := parseRetryAfter("120")
+	if got != 120*time.Second {
+		t.Errorf("parseRetryAfter(\"120\") = %v, want %v", got, 120*time.Second)
+	}
+}
+
+func TestParseRetryAfterHTTPDate(t *testing.T) {
+	when := time.Now().Add(1 * time.Minute).UTC()
+	header := when.Format(http.TimeFormat)
+
+	got := parseRetryAfter(header)
+	if got <= 0 || got > 2*time.Minute {
+		t.Errorf("parseRetryAfter(%q) = %v, want a positive duration close to 1m", header, got)
+	}
+}
+
+func TestParseRetryAfterEmptyOrInvalid(t *testing.T) {
+	if got := parseRetryAfter(""); got != 0 {
+		t.Errorf("parseRetryAfter(\"\") = %v, want 0", got)
+	}
+	if got := parseRetryAfter("not-a-valid-value"); got != 0 {
+		t.Errorf("parseRetryAfter(invalid) = %v, want 0", got)
+	}
+}
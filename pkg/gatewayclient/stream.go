@@ -0,0 +1,136 @@
+package gatewayclient
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// scanSSEEvents is a bufio.SplitFunc that splits an SSE byte stream into
+// individual events, delimited by a blank line in either "\n\n" or
+// "\r\n\r\n" form per the WHATWG SSE spec. When a Scan call buffers more
+// than one event, the two delimiters must be compared by which occurs
+// earliest in data, not by a fixed precedence, or events following a
+// "\n\n" that appears before a later "\r\n\r\n" get merged into one.
+func scanSSEEvents(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+
+	idxLF := bytes.Index(data, []byte("\n\n"))
+	idxCRLF := bytes.Index(data, []byte("\r\n\r\n"))
+
+	switch {
+	case idxLF >= 0 && (idxCRLF < 0 || idxLF <= idxCRLF):
+		return idxLF + 2, data[:idxLF], nil
+	case idxCRLF >= 0:
+		return idxCRLF + 4, data[:idxCRLF], nil
+	}
+
+	if atEOF {
+		return len(data), data, nil
+	}
+
+	return 0, nil, nil
+}
+
+// parseSSEEvent parses a single raw SSE event block into a StreamEvent.
+// Unknown fields are ignored per spec; multiple "data:" lines are joined
+// with newlines.
+func parseSSEEvent(raw string) StreamEvent {
+	var event StreamEvent
+	var dataLines []string
+
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		switch {
+		case strings.HasPrefix(line, "event:"):
+			event.EventType = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			dataLines = append(dataLines, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		case strings.HasPrefix(line, "id:"):
+			event.ID = strings.TrimSpace(strings.TrimPrefix(line, "id:"))
+		case strings.HasPrefix(line, "retry:"):
+			if retry, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "retry:"))); err == nil {
+				event.Retry = retry
+			}
+		}
+	}
+
+	event.Data = strings.Join(dataLines, "\n")
+	return event
+}
+
+// StreamAgent invokes an A2A agent with streaming enabled and returns the
+// parsed SSE events on a channel, along with a channel carrying at most one
+// terminal error. Both channels are closed when the stream ends. Streaming
+// requests are never retried by doWithRetry's policy, since partial output
+// may already have been emitted to the caller.
+func (c *Client) StreamAgent(ctx context.Context, agentID int, message string) (<-chan StreamEvent, <-chan error) {
+	events := make(chan StreamEvent)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(events)
+		defer close(errs)
+
+		req, err := c.newInvokeRequest(ctx, agentID, message, true)
+		if err != nil {
+			errs <- err
+			return
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			errs <- fmt.Errorf("request failed: %w", err)
+			return
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			requestID := resp.Header.Get("X-Request-ID")
+			switch resp.StatusCode {
+			case http.StatusTooManyRequests:
+				errs <- &RateLimitError{Message: string(body), RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")), RequestID: requestID}
+			case http.StatusUnauthorized, http.StatusForbidden:
+				errs <- &AuthError{StatusCode: resp.StatusCode, RequestID: requestID}
+			case http.StatusNotFound:
+				errs <- &AgentNotFoundError{AgentID: agentID, RequestID: requestID}
+			default:
+				errs <- &GatewayError{StatusCode: resp.StatusCode, Message: string(body), RequestID: requestID}
+			}
+			return
+		}
+
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 4096), 1<<20)
+		scanner.Split(scanSSEEvents)
+
+		for scanner.Scan() {
+			select {
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			default:
+			}
+
+			raw := scanner.Text()
+			if strings.TrimSpace(raw) == "" {
+				continue
+			}
+			events <- parseSSEEvent(raw)
+		}
+
+		if err := scanner.Err(); err != nil {
+			errs <- fmt.Errorf("failed to read stream: %w", err)
+		}
+	}()
+
+	return events, errs
+}
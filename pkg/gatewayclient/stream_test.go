@@ -0,0 +1,129 @@
+package gatewayclient
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestScanSSEEvents(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "single LF-delimited event",
+			input: "event: a\ndata: hello\n\n",
+			want:  []string{"event: a\ndata: hello"},
+		},
+		{
+			name:  "single CRLF-delimited event",
+			input: "event: a\r\ndata: hello\r\n\r\n",
+			want:  []string{"event: a\r\ndata: hello"},
+		},
+		{
+			name:  "LF event followed by CRLF event in one buffer",
+			input: "event: a\ndata: first\n\nevent: b\r\ndata: second\r\n\r\n",
+			want: []string{
+				"event: a\ndata: first",
+				"event: b\r\ndata: second",
+			},
+		},
+		{
+			name:  "CRLF event followed by LF event in one buffer",
+			input: "event: a\r\ndata: first\r\n\r\nevent: b\ndata: second\n\n",
+			want: []string{
+				"event: a\r\ndata: first",
+				"event: b\ndata: second",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scanner := bufio.NewScanner(strings.NewReader(tt.input))
+			scanner.Split(scanSSEEvents)
+
+			var got []string
+			for scanner.Scan() {
+				got = append(got, scanner.Text())
+			}
+			if err := scanner.Err(); err != nil {
+				t.Fatalf("scanner error: %v", err)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d events %v, want %d events %v", len(got), got, len(tt.want), tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("event %d: got %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseSSEEvent(t *testing.T) {
+	event := parseSSEEvent("event: message\ndata: line one\ndata: line two\nid: 42\nretry: 3000")
+
+	if event.EventType != "message" {
+		t.Errorf("EventType = %q, want %q", event.EventType, "message")
+	}
+	if event.Data != "line one\nline two" {
+		t.Errorf("Data = %q, want %q", event.Data, "line one\nline two")
+	}
+	if event.ID != "42" {
+		t.Errorf("ID = %q, want %q", event.ID, "42")
+	}
+	if event.Retry != 3000 {
+		t.Errorf("Retry = %d, want %d", event.Retry, 3000)
+	}
+}
+
+func TestStreamAgentSplitsEventsBufferedTogether(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "event: greeting\ndata: hello\n\nevent: greeting\r\ndata: world\r\n\r\n")
+	}))
+	defer server.Close()
+
+	client := New(server.URL, "test-key")
+	events, errs := client.StreamAgent(context.Background(), 1, "hi")
+
+	var got []StreamEvent
+	for events != nil || errs != nil {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			got = append(got, event)
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			if err != nil {
+				t.Fatalf("unexpected stream error: %v", err)
+			}
+		}
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("got %d events, want 2: %+v", len(got), got)
+	}
+	if got[0].Data != "hello" {
+		t.Errorf("event 0 Data = %q, want %q", got[0].Data, "hello")
+	}
+	if got[1].Data != "world" {
+		t.Errorf("event 1 Data = %q, want %q", got[1].Data, "world")
+	}
+}
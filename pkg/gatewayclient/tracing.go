@@ -0,0 +1,96 @@
+package gatewayclient
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceContextKey is the context.Context key under which a TraceContext is
+// stored.
+type traceContextKey struct{}
+
+// TraceContext is the W3C Trace Context carried on a request, so agent
+// invocations can be correlated end-to-end with the Gateway's own traces.
+type TraceContext struct {
+	// TraceID is the 32-hex-character trace identifier.
+	TraceID string
+	// SpanID is the 16-hex-character parent span identifier.
+	SpanID string
+	// Sampled marks whether this trace is sampled (traceparent flags).
+	Sampled bool
+	// TraceState carries vendor-specific tracestate, if any.
+	TraceState string
+}
+
+// WithTraceContext returns a copy of ctx carrying tc, to be picked up by the
+// Client's Propagator on the next outgoing request.
+func WithTraceContext(ctx context.Context, tc TraceContext) context.Context {
+	return context.WithValue(ctx, traceContextKey{}, tc)
+}
+
+// TraceContextFromContext extracts the TraceContext previously attached
+// with WithTraceContext, if any.
+func TraceContextFromContext(ctx context.Context) (TraceContext, bool) {
+	tc, ok := ctx.Value(traceContextKey{}).(TraceContext)
+	return tc, ok
+}
+
+// Propagator injects distributed-tracing headers derived from ctx onto an
+// outgoing request. Implementations should be safe to call even when ctx
+// carries no trace context.
+type Propagator interface {
+	Inject(ctx context.Context, header http.Header)
+}
+
+// W3CPropagator is the default Propagator. It injects the W3C Trace Context
+// `traceparent`/`tracestate` headers (https://www.w3.org/TR/trace-context/),
+// the format OpenTelemetry SDKs read and write natively. It sources the
+// trace from, in order: a TraceContext explicitly attached with
+// WithTraceContext, or otherwise the active OpenTelemetry span carried on
+// ctx via the otel/trace context propagation conventions — so a caller
+// that's already instrumented with the OTel SDK gets correlated traces for
+// free, with no per-call-site translation required.
+type W3CPropagator struct{}
+
+// Inject writes traceparent/tracestate sourced from ctx, if a trace is
+// present.
+func (W3CPropagator) Inject(ctx context.Context, header http.Header) {
+	if tc, ok := TraceContextFromContext(ctx); ok && tc.TraceID != "" && tc.SpanID != "" {
+		injectTraceparent(header, tc.TraceID, tc.SpanID, tc.Sampled, tc.TraceState)
+		return
+	}
+
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return
+	}
+	injectTraceparent(header, sc.TraceID().String(), sc.SpanID().String(), sc.IsSampled(), sc.TraceState().String())
+}
+
+// injectTraceparent writes the traceparent/tracestate headers for the given
+// trace/span IDs, regardless of which source they came from.
+func injectTraceparent(header http.Header, traceID, spanID string, sampled bool, traceState string) {
+	flags := "00"
+	if sampled {
+		flags = "01"
+	}
+	header.Set("traceparent", "00-"+traceID+"-"+spanID+"-"+flags)
+	if traceState != "" {
+		header.Set("tracestate", traceState)
+	}
+}
+
+// newRequestID generates a random 16-byte request identifier for the
+// X-Request-ID header, used when the caller hasn't supplied one via
+// WithBaseHeaders.
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
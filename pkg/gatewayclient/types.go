@@ -0,0 +1,58 @@
+package gatewayclient
+
+// Agent describes an A2A agent accessible through the Gateway.
+type Agent struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// MessagePart is a single piece of a Message, e.g. a block of text.
+type MessagePart struct {
+	Kind string `json:"kind"`
+	Text string `json:"text"`
+}
+
+// Message is the request/response envelope used by the A2A invoke API.
+type Message struct {
+	Role  string        `json:"role"`
+	Parts []MessagePart `json:"parts"`
+}
+
+// InvokeResult is the decoded, successful result of a non-streaming
+// InvokeAgent call.
+type InvokeResult struct {
+	Data interface{}
+}
+
+// StreamEvent is a single event emitted by StreamAgent (parsed from SSE
+// text) or received over a WebSocket AgentSession (unmarshaled as JSON
+// using the tags below, matching the SSE spec's field names).
+type StreamEvent struct {
+	EventType string `json:"event"`
+	Data      string `json:"data"`
+	ID        string `json:"id"`
+	Retry     int    `json:"retry"`
+}
+
+// invokeAgentRequest is the wire format for an invoke call.
+type invokeAgentRequest struct {
+	Message   Message `json:"message"`
+	Streaming bool    `json:"streaming,omitempty"`
+}
+
+// invokeAgentResponse is the wire format for an invoke response.
+type invokeAgentResponse struct {
+	Success   bool        `json:"success"`
+	Data      interface{} `json:"data,omitempty"`
+	Error     string      `json:"error,omitempty"`
+	ErrorCode string      `json:"errorCode,omitempty"`
+}
+
+// listAgentsResponse is the wire format for the list-agents response.
+type listAgentsResponse struct {
+	Success bool `json:"success"`
+	Data    struct {
+		Agents []Agent `json:"agents"`
+	} `json:"data"`
+}
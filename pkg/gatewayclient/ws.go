@@ -0,0 +1,82 @@
+package gatewayclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/websocket"
+)
+
+// AgentSession is a full-duplex connection to an agent over WebSocket,
+// allowing multiple turns and mid-generation cancellation that the
+// POST+SSE transport cannot express.
+type AgentSession struct {
+	conn   *websocket.Conn
+	cancel context.CancelFunc
+}
+
+// InvokeAgentWS opens a WebSocket session with the given agent for a
+// multi-turn, bidirectional conversation.
+func (c *Client) InvokeAgentWS(ctx context.Context, agentID int) (*AgentSession, error) {
+	wsURL := strings.Replace(c.baseURL, "http://", "ws://", 1)
+	wsURL = strings.Replace(wsURL, "https://", "wss://", 1)
+	wsURL = fmt.Sprintf("%s/api/v1/agents/%d/ws", wsURL, agentID)
+
+	dialer := websocket.Dialer{}
+	conn, resp, err := dialer.DialContext(ctx, wsURL, c.newRequestHeaders(ctx))
+	if err != nil {
+		if resp != nil {
+			requestID := resp.Header.Get("X-Request-ID")
+			switch resp.StatusCode {
+			case http.StatusTooManyRequests:
+				return nil, &RateLimitError{Message: err.Error(), RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")), RequestID: requestID}
+			case http.StatusUnauthorized, http.StatusForbidden:
+				return nil, &AuthError{StatusCode: resp.StatusCode, RequestID: requestID}
+			case http.StatusNotFound:
+				return nil, &AgentNotFoundError{AgentID: agentID, RequestID: requestID}
+			}
+			return nil, &GatewayError{StatusCode: resp.StatusCode, Message: err.Error(), RequestID: requestID}
+		}
+		return nil, fmt.Errorf("websocket dial failed: %w", err)
+	}
+
+	sessionCtx, cancel := context.WithCancel(ctx)
+	session := &AgentSession{conn: conn, cancel: cancel}
+
+	go func() {
+		<-sessionCtx.Done()
+		_ = conn.Close()
+	}()
+
+	return session, nil
+}
+
+// Send writes a user message to the session as a single JSON frame.
+func (s *AgentSession) Send(msg string) error {
+	frame := Message{Role: "user", Parts: []MessagePart{{Kind: "text", Text: msg}}}
+	return s.conn.WriteJSON(frame)
+}
+
+// Recv blocks until the next agent event frame arrives on the session.
+func (s *AgentSession) Recv() (StreamEvent, error) {
+	var raw json.RawMessage
+	if err := s.conn.ReadJSON(&raw); err != nil {
+		return StreamEvent{}, fmt.Errorf("failed to read websocket frame: %w", err)
+	}
+
+	var event StreamEvent
+	if err := json.Unmarshal(raw, &event); err != nil {
+		return StreamEvent{}, fmt.Errorf("failed to decode agent event: %w", err)
+	}
+
+	return event, nil
+}
+
+// Close ends the session and releases the underlying connection.
+func (s *AgentSession) Close() error {
+	s.cancel()
+	return s.conn.Close()
+}